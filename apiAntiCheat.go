@@ -0,0 +1,154 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/taubyte/go-sdk/database"
+)
+
+// Path of the database recording submissions that failed validation.
+const rejectedDB = "/rejected"
+
+// minMillisPerEvent is the fastest a human player could plausibly generate
+// consecutive game events; submissions faster than this are rejected by
+// rateLimitValidator.
+const minMillisPerEvent = 50
+
+// Validator checks a game submission for legitimacy. It returns the score
+// it independently computed for the submission (authoritative only for the
+// validator that runs last — see defaultValidators), a human-readable
+// reason on rejection, and whether the submission passed.
+type Validator interface {
+	Validate(req GameStateReq) (score int, reason string, ok bool)
+}
+
+// defaultValidators is the pipeline `set` runs every submission through, in
+// order. The last validator's score is the one awarded, so the most
+// authoritative check (the physics replay) runs last.
+var defaultValidators = []Validator{
+	monotonicTimestampValidator{},
+	rateLimitValidator{},
+	physicsReplayValidator{},
+}
+
+// monotonicTimestampValidator rejects submissions whose event timestamps
+// run backwards. This is the original, simplest anti-cheat check.
+type monotonicTimestampValidator struct{}
+
+func (monotonicTimestampValidator) Validate(req GameStateReq) (int, string, bool) {
+	var last int64 = -1
+	for _, ev := range req.GameEvents {
+		if last >= 0 && ev.Timestamp < last {
+			return 0, "event timestamps are not monotonically increasing", false
+		}
+		last = ev.Timestamp
+	}
+	return req.FinalBlockCount, "", true
+}
+
+// rateLimitValidator rejects runs whose event rate is implausibly fast for
+// a human player, comparing GameDuration against the number of events.
+type rateLimitValidator struct{}
+
+func (rateLimitValidator) Validate(req GameStateReq) (int, string, bool) {
+	if len(req.GameEvents) == 0 {
+		return req.FinalBlockCount, "", true
+	}
+	if req.GameDuration < int64(len(req.GameEvents))*minMillisPerEvent {
+		return 0, "too many events for the reported game duration", false
+	}
+	return req.FinalBlockCount, "", true
+}
+
+// physicsReplayValidator reconstructs the tower by replaying GameEvents and
+// verifies FinalBlockCount against the number of placements whose block and
+// target actually overlap on the XZ plane, instead of trusting the client's
+// reported total outright.
+type physicsReplayValidator struct{}
+
+func (physicsReplayValidator) Validate(req GameStateReq) (int, string, bool) {
+	successful := countSuccessfulPlacements(req.GameEvents)
+	if len(req.GameEvents) > 0 && successful != req.FinalBlockCount {
+		return successful, fmt.Sprintf(
+			"final_block_count %d does not match %d verified placements",
+			req.FinalBlockCount, successful,
+		), false
+	}
+	return successful, "", true
+}
+
+// countSuccessfulPlacements replays GameEvents and counts block placements
+// whose block and target overlap on the XZ plane.
+func countSuccessfulPlacements(events []GameEvent) int {
+	successful := 0
+	for _, ev := range events {
+		if ev.EventType != "block_placed" {
+			continue
+		}
+		if overlapsXZ(ev.BlockPosition, ev.BlockScale, ev.TargetPosition, ev.TargetScale) {
+			successful++
+		}
+	}
+	return successful
+}
+
+// overlapsXZ reports whether a placed block and its target still overlap on
+// the XZ plane, treating position as each box's center.
+func overlapsXZ(blockPos, blockScale, targetPos, targetScale Vec3) bool {
+	return axisOverlaps(blockPos.X, blockScale.X, targetPos.X, targetScale.X) &&
+		axisOverlaps(blockPos.Z, blockScale.Z, targetPos.Z, targetScale.Z)
+}
+
+// axisOverlaps reports whether two centered intervals on one axis overlap.
+func axisOverlaps(aPos, aScale, bPos, bScale float64) bool {
+	aMin, aMax := aPos-aScale/2, aPos+aScale/2
+	bMin, bMax := bPos-bScale/2, bPos+bScale/2
+	return aMin < bMax && bMin < aMax
+}
+
+// validateSubmission runs a submission through validators in order,
+// stopping at the first rejection. On success it returns the score
+// computed by the last validator to run.
+func validateSubmission(req GameStateReq, validators []Validator) (score int, reason string, ok bool) {
+	for _, v := range validators {
+		s, r, passed := v.Validate(req)
+		if !passed {
+			return s, r, false
+		}
+		score = s
+	}
+	return score, "", true
+}
+
+// RejectedSubmission records a submission that failed validation, so
+// operators can inspect suspicious activity.
+type RejectedSubmission struct {
+	PlayerName string       `json:"player_name"`
+	Reason     string       `json:"reason"`
+	Submission GameStateReq `json:"submission"`
+	RejectedAt int64        `json:"rejected_at"`
+}
+
+// recordRejected stores a rejected submission in the /rejected bucket.
+func recordRejected(req GameStateReq, reason string) error {
+	db, err := database.New(rejectedDB)
+	if err != nil {
+		return err
+	}
+
+	rejected := RejectedSubmission{
+		PlayerName: req.PlayerName,
+		Reason:     reason,
+		Submission: req,
+		RejectedAt: time.Now().UnixNano(),
+	}
+	raw, err := json.Marshal(rejected)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s/%d", req.PlayerName, rejected.RejectedAt)
+	return db.Put(key, raw)
+}
@@ -0,0 +1,61 @@
+package lib
+
+import "testing"
+
+func TestOverlapsXZ(t *testing.T) {
+	cases := []struct {
+		name                   string
+		blockPos, blockScale   Vec3
+		targetPos, targetScale Vec3
+		want                   bool
+	}{
+		{
+			name:        "centered exact match",
+			blockPos:    Vec3{X: 0, Y: 0, Z: 0},
+			blockScale:  Vec3{X: 1, Y: 1, Z: 1},
+			targetPos:   Vec3{X: 0, Y: 0, Z: 0},
+			targetScale: Vec3{X: 1, Y: 1, Z: 1},
+			want:        true,
+		},
+		{
+			name:        "partial overlap on both axes",
+			blockPos:    Vec3{X: 0.5, Y: 0, Z: 0.5},
+			blockScale:  Vec3{X: 1, Y: 1, Z: 1},
+			targetPos:   Vec3{X: 0, Y: 0, Z: 0},
+			targetScale: Vec3{X: 1, Y: 1, Z: 1},
+			want:        true,
+		},
+		{
+			name:        "clear miss on X",
+			blockPos:    Vec3{X: 5, Y: 0, Z: 0},
+			blockScale:  Vec3{X: 1, Y: 1, Z: 1},
+			targetPos:   Vec3{X: 0, Y: 0, Z: 0},
+			targetScale: Vec3{X: 1, Y: 1, Z: 1},
+			want:        false,
+		},
+		{
+			name:        "overlaps on X but misses on Z",
+			blockPos:    Vec3{X: 0, Y: 0, Z: 5},
+			blockScale:  Vec3{X: 1, Y: 1, Z: 1},
+			targetPos:   Vec3{X: 0, Y: 0, Z: 0},
+			targetScale: Vec3{X: 1, Y: 1, Z: 1},
+			want:        false,
+		},
+		{
+			name:        "touching edges do not overlap",
+			blockPos:    Vec3{X: 1, Y: 0, Z: 0},
+			blockScale:  Vec3{X: 1, Y: 1, Z: 1},
+			targetPos:   Vec3{X: 0, Y: 0, Z: 0},
+			targetScale: Vec3{X: 1, Y: 1, Z: 1},
+			want:        false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := overlapsXZ(c.blockPos, c.blockScale, c.targetPos, c.targetScale); got != c.want {
+				t.Errorf("overlapsXZ() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/taubyte/go-sdk/database"
+	"github.com/taubyte/go-sdk/event"
+)
+
+// Path of the board configuration database, keyed by board_id.
+const boardsConfigDB = "/boards"
+
+// defaultBoardID is used whenever a request omits `board_id`, preserving
+// the original single-leaderboard behaviour.
+const defaultBoardID = "default"
+
+// BoardConfig describes one named leaderboard's ranking behaviour, modeled
+// after Nakama's leaderboard create API. Unlike tournaments, boards don't
+// reset on a schedule — a board's award log and score index persist until
+// deleteBoard removes them.
+type BoardConfig struct {
+	ID          string `json:"id"`
+	Operator    string `json:"operator"`   // "best", "set", or "increment"
+	SortOrder   string `json:"sort_order"` // "asc" or "desc"
+	EnableRanks bool   `json:"enable_ranks"`
+}
+
+// defaultBoardConfig is the implicit configuration for any board_id that has
+// never been explicitly created via createBoard.
+func defaultBoardConfig(boardID string) BoardConfig {
+	return BoardConfig{
+		ID:          boardID,
+		Operator:    "best",
+		SortOrder:   "desc",
+		EnableRanks: true,
+	}
+}
+
+// boardDataPath returns the key/value database path storing award logs for
+// a given board.
+func boardDataPath(boardID string) string {
+	return leaderboardDB + "/" + boardID
+}
+
+// boardScoreIndexPath returns the database path storing the sorted
+// score → player secondary index for a given board.
+func boardScoreIndexPath(boardID string) string {
+	return leaderboardByScoreDB + "/" + boardID
+}
+
+// operatorFoldMode maps a board's operator to the award-log fold mode used
+// to compute its ranked score.
+func operatorFoldMode(operator string) string {
+	switch operator {
+	case "increment":
+		return "sum"
+	case "set":
+		return "last"
+	default: // "best"
+		return "max"
+	}
+}
+
+// loadBoardConfig returns a board's configuration, or the implicit default
+// if it was never created via createBoard.
+func loadBoardConfig(boardID string) (BoardConfig, error) {
+	db, err := database.New(boardsConfigDB)
+	if err != nil {
+		return BoardConfig{}, err
+	}
+
+	raw, err := db.Get(boardID)
+	if err != nil || len(raw) == 0 {
+		return defaultBoardConfig(boardID), nil
+	}
+
+	var cfg BoardConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return BoardConfig{}, err
+	}
+	return cfg, nil
+}
+
+// createBoard → Creates or replaces a named leaderboard's configuration.
+// Body: {"id", "operator", "sort_order", "enable_ranks"}.
+//
+//export createBoard
+func createBoard(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	var cfg BoardConfig
+	dec := json.NewDecoder(h.Body())
+	defer h.Body().Close()
+	if err = dec.Decode(&cfg); err != nil {
+		return fail(h, err, 400)
+	}
+
+	if cfg.ID == "" {
+		return fail(h, errors.New("id is required"), 400)
+	}
+	switch cfg.Operator {
+	case "best", "set", "increment":
+	default:
+		return fail(h, errors.New("operator must be one of best, set, increment"), 400)
+	}
+	switch cfg.SortOrder {
+	case "asc", "desc":
+	default:
+		return fail(h, errors.New("sort_order must be asc or desc"), 400)
+	}
+
+	db, err := database.New(boardsConfigDB)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	if err = db.Put(cfg.ID, raw); err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Return(200)
+	return 0
+}
+
+// deleteBoard → Deletes a named leaderboard's configuration along with its
+// award logs and score index (via query param `board_id`).
+//
+//export deleteBoard
+func deleteBoard(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	boardID, err := h.Query().Get("board_id")
+	if err != nil || boardID == "" {
+		return fail(h, errors.New("board_id is required"), 400)
+	}
+
+	if err := purgeBoardData(boardID); err != nil {
+		return fail(h, err, 500)
+	}
+
+	db, err := database.New(boardsConfigDB)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	db.Delete(boardID) // best-effort: board may never have been created
+
+	h.Return(200)
+	return 0
+}
+
+// disableRanks → Turns off rank tracking for a board (via query param
+// `board_id`) and drops its score index, so high-write boards stop paying
+// for sorted order they don't need.
+//
+//export disableRanks
+func disableRanks(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	boardID, err := h.Query().Get("board_id")
+	if err != nil || boardID == "" {
+		return fail(h, errors.New("board_id is required"), 400)
+	}
+
+	cfg, err := loadBoardConfig(boardID)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	cfg.EnableRanks = false
+	cfg.ID = boardID
+
+	db, err := database.New(boardsConfigDB)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	if err = db.Put(boardID, raw); err != nil {
+		return fail(h, err, 500)
+	}
+
+	// The index is no longer maintained once ranks are disabled; drop what
+	// already exists so stale entries can't leak into `list`.
+	if idx, err := database.New(boardScoreIndexPath(boardID)); err == nil {
+		if keys, err := idx.List(""); err == nil {
+			for _, key := range keys {
+				idx.Delete(strings.Trim(key, "/"))
+			}
+		}
+	}
+
+	h.Return(200)
+	return 0
+}
+
+// purgeBoardData removes every award log and score index entry for a board.
+func purgeBoardData(boardID string) error {
+	db, err := database.New(boardDataPath(boardID))
+	if err != nil {
+		return err
+	}
+	keys, err := db.List("")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		db.Delete(strings.Trim(key, "/"))
+	}
+
+	idx, err := database.New(boardScoreIndexPath(boardID))
+	if err != nil {
+		return err
+	}
+	idxKeys, err := idx.List("")
+	if err != nil {
+		return err
+	}
+	for _, key := range idxKeys {
+		idx.Delete(strings.Trim(key, "/"))
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package lib
+
+import (
+	dlist "container/list"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/taubyte/go-sdk/event"
+)
+
+// Env vars controlling cache capacity, read once at init.
+const (
+	envTopKCacheSize        = "LEADERBOARD_CACHE_TOPK_SIZE"
+	envPlayerScoreCacheSize = "LEADERBOARD_CACHE_PLAYER_SCORE_SIZE"
+	envListingCacheSize     = "LEADERBOARD_CACHE_LISTING_SIZE"
+
+	defaultTopKCacheSize        = 1
+	defaultPlayerScoreCacheSize = 1024
+	defaultListingCacheSize     = 64
+)
+
+// lru is a minimal fixed-capacity least-recently-used cache, safe only when
+// the caller holds leaderboardCache.mu.
+type lru struct {
+	capacity int
+	items    map[string]*dlist.Element
+	order    *dlist.List
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{capacity: capacity, items: make(map[string]*dlist.Element), order: dlist.New()}
+}
+
+func (c *lru) get(key string) (interface{}, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+func (c *lru) put(key string, value interface{}) {
+	if c.capacity <= 0 {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	el := c.order.PushFront(&lruEntry{key: key, value: value})
+	c.items[key] = el
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
+
+func (c *lru) delete(key string) {
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+func (c *lru) deletePrefix(prefix string) {
+	for key := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.delete(key)
+		}
+	}
+}
+
+// inflightCall lets concurrent misses for the same key share one DB fetch.
+type inflightCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+// leaderboardCache sits in front of the leaderboard databases for the
+// mostly-read getAll/get/list/subscribe paths.
+type leaderboardCache struct {
+	mu             sync.RWMutex
+	lruTopK        *lru
+	lruPlayerScore *lru
+	lruListing     *lru
+	inflight       map[string]*inflightCall
+
+	hits, misses int64
+}
+
+var cache = newLeaderboardCache()
+
+func newLeaderboardCache() *leaderboardCache {
+	return &leaderboardCache{
+		lruTopK:        newLRU(envInt(envTopKCacheSize, defaultTopKCacheSize)),
+		lruPlayerScore: newLRU(envInt(envPlayerScoreCacheSize, defaultPlayerScoreCacheSize)),
+		lruListing:     newLRU(envInt(envListingCacheSize, defaultListingCacheSize)),
+		inflight:       make(map[string]*inflightCall),
+	}
+}
+
+func envInt(name string, def int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
+
+// getOrLoad reads from the given LRU, falling back to fn on a miss. Misses
+// for the same key made while another is already in flight share the one
+// underlying fetch instead of each hitting the database.
+func (c *leaderboardCache) getOrLoad(cacheName string, l *lru, key string, fn func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if v, ok := l.get(key); ok {
+		c.mu.Unlock()
+		atomic.AddInt64(&c.hits, 1)
+		return v, nil
+	}
+
+	inflightKey := cacheName + ":" + key
+	if call, ok := c.inflight[inflightKey]; ok {
+		c.mu.Unlock()
+		atomic.AddInt64(&c.hits, 1) // collapsed into an in-flight fetch, not a fresh DB hit
+		call.wg.Wait()
+		return call.value, call.err
+	}
+
+	call := &inflightCall{}
+	call.wg.Add(1)
+	c.inflight[inflightKey] = call
+	atomic.AddInt64(&c.misses, 1)
+	c.mu.Unlock()
+
+	call.value, call.err = fn()
+	call.wg.Done()
+
+	c.mu.Lock()
+	delete(c.inflight, inflightKey)
+	if call.err == nil {
+		l.put(key, call.value)
+	}
+	c.mu.Unlock()
+
+	return call.value, call.err
+}
+
+// invalidateBoardCache drops cached entries that a `set` on boardID may
+// have made stale: the player's cached score always, the board's cached
+// top-K only if newScore could plausibly belong in it, and every cached
+// listing page for the board (a changed score can shift any page's cursor
+// boundaries).
+func invalidateBoardCache(boardID, player string, newScore int) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.lruPlayerScore.deletePrefix(boardID + "/" + player + "/")
+	cache.lruListing.deletePrefix(boardID + "/")
+
+	if scoreWouldEnterTopKLocked(boardID, newScore) {
+		cache.lruTopK.deletePrefix(boardID + "/")
+	}
+}
+
+// scoreWouldEnterTopKLocked reports whether newScore could belong in a
+// board's cached top-K, using only the cached snapshot (no DB access).
+// Callers must hold cache.mu.
+func scoreWouldEnterTopKLocked(boardID string, newScore int) bool {
+	found := false
+	for key, el := range cache.lruTopK.items {
+		if !strings.HasPrefix(key, boardID+"/") {
+			continue
+		}
+		found = true
+
+		entries, ok := el.Value.(*lruEntry).value.([]topKEntry)
+		if !ok {
+			return true
+		}
+
+		// The key itself (boardID/k, via cachedTopKKey) carries the depth
+		// this snapshot was cached for — not every board's cache uses
+		// defaultTopK, so that depth has to come from the key, not a
+		// constant.
+		k := defaultTopK
+		if idx := strings.LastIndex(key, "/"); idx >= 0 {
+			if parsed, err := strconv.Atoi(key[idx+1:]); err == nil {
+				k = parsed
+			}
+		}
+
+		if len(entries) < k || newScore >= entries[len(entries)-1].Score {
+			return true
+		}
+	}
+	return !found // nothing cached yet: assume it could matter
+}
+
+// stats → Returns cache hit/miss counters as JSON.
+//
+//export stats
+func stats(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	hits := atomic.LoadInt64(&cache.hits)
+	misses := atomic.LoadInt64(&cache.misses)
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"hits":   hits,
+		"misses": misses,
+	})
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Headers().Set("Content-Type", "application/json")
+	h.Write(jsonData)
+	h.Return(200)
+	return 0
+}
+
+// cachedTopKKey builds the lruTopK cache key for a board at a given depth.
+func cachedTopKKey(boardID string, k int) string {
+	return fmt.Sprintf("%s/%d", boardID, k)
+}
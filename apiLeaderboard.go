@@ -2,9 +2,12 @@ package lib
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/taubyte/go-sdk/database"
 	"github.com/taubyte/go-sdk/event"
@@ -18,6 +21,23 @@ func fail(h http.Event, err error, code int) uint32 {
 	return 1
 }
 
+// errPlayerNotFound is returned by get's cache-populating loader so callers
+// can distinguish "no such player" from other failures without caching it.
+var errPlayerNotFound = errors.New("player not found")
+
+// Path of the leaderboard key/value database
+const leaderboardDB = "/leaderboard"
+
+// Path of the secondary index mapping zero-padded score → player, kept in
+// sync with leaderboardDB so ranked pagination never has to scan every key.
+const leaderboardByScoreDB = "/leaderboard_by_score"
+
+// Default and maximum page size for the `list` handler.
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
 // ===== Data Structures =====
 
 // Represents a 3D vector (used for block position/scale)
@@ -46,32 +66,158 @@ type GameStateReq struct {
 	FinalBlockCount int         `json:"final_block_count"`
 }
 
+// Award is a single entry in a player's append-only score log. Scores are
+// never overwritten in place; a player's displayed score is always folded
+// from their full award history at read time.
+type Award struct {
+	When       int64  `json:"when"`
+	PlayerName string `json:"player_name"`
+	Category   string `json:"category"`
+	Points     int    `json:"points"`
+}
+
 // ===== Utility Functions =====
 
-// Compute score from the player's final block count
-func computeScore(req GameStateReq) int {
-	// Simple anti-cheat: check if timestamps are chronologically correct
-	if len(req.GameEvents) > 0 {
-		// Check if timestamps are in chronological order
-		var lastTimestamp int64 = -1
-		for _, ev := range req.GameEvents {
-			if lastTimestamp >= 0 && ev.Timestamp < lastTimestamp {
-				return 0 // Suspicious: timestamps going backwards
+// queryParam reads an optional query string parameter, falling back to def
+// when it is absent or empty.
+func queryParam(h http.Event, key, def string) string {
+	if v, err := h.Query().Get(key); err == nil && v != "" {
+		return v
+	}
+	return def
+}
+
+// loadAwards returns a player's full award log, or an empty log if the
+// player has never submitted a score.
+func loadAwards(db database.Database, player string) ([]Award, error) {
+	raw, err := db.Get(player)
+	if err != nil || len(raw) == 0 {
+		return nil, nil
+	}
+
+	var awards []Award
+	if err := json.Unmarshal(raw, &awards); err != nil {
+		return nil, err
+	}
+	return awards, nil
+}
+
+// appendAwards adds newAwards to the end of a player's award log and returns
+// the player's ranked score (folded with foldMode, per the board's operator)
+// before and after the append, so callers can decide whether the score
+// secondary index needs updating.
+func appendAwards(db database.Database, player string, newAwards []Award, foldMode string) (oldScore, newScore int, err error) {
+	existing, err := loadAwards(db, player)
+	if err != nil {
+		return 0, 0, err
+	}
+	oldScore = foldAwards(existing, foldMode)
+
+	existing = append(existing, newAwards...)
+	raw, err := json.Marshal(existing)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err = db.Put(player, raw); err != nil {
+		return 0, 0, err
+	}
+
+	newScore = foldAwards(existing, foldMode)
+	return oldScore, newScore, nil
+}
+
+// scoreKey builds a /leaderboard_by_score index key for a player's score.
+// Zero-padding the score keeps the lexical key order equal to numeric order.
+func scoreKey(score int, player string) string {
+	return fmt.Sprintf("%020d/%s", score, player)
+}
+
+// updateScoreIndex keeps a score secondary index (board- or tournament-
+// window-scoped) in sync with a player's ranked score after a `set` call
+// changes it.
+func updateScoreIndex(indexPath, player string, oldScore, newScore int) error {
+	idx, err := database.New(indexPath)
+	if err != nil {
+		return err
+	}
+
+	// Best-effort: the old entry may not exist yet (a player's first score).
+	idx.Delete(scoreKey(oldScore, player))
+
+	return idx.Put(scoreKey(newScore, player), []byte(player))
+}
+
+// deriveAwards turns an already-validated game submission into a single
+// per-run award whose Points is the run's tower height, so folding a
+// player's log with "max" recovers their best single run instead of being
+// diluted by a flood of 1-point-per-placement awards. verifiedCount is
+// validateSubmission's verified block count for this run; the starting
+// block doesn't count toward height.
+func deriveAwards(req GameStateReq, verifiedCount int) []Award {
+	if verifiedCount <= 0 {
+		return nil
+	}
+
+	when := req.GameDuration
+	if n := len(req.GameEvents); n > 0 {
+		when = req.GameEvents[n-1].Timestamp
+	}
+
+	return []Award{{
+		When:       when,
+		PlayerName: req.PlayerName,
+		Category:   "height",
+		Points:     verifiedCount - 1,
+	}}
+}
+
+// foldAwards is the pure reducer that turns an award log into a single
+// displayed score. mode selects the fold:
+//   - "max" (default): the single highest award, i.e. the "best" operator
+//   - "sum": the total of every award, i.e. the "increment" operator
+//   - "last": the most recent award, i.e. the "set" operator
+func foldAwards(awards []Award, mode string) int {
+	switch mode {
+	case "sum":
+		total := 0
+		for _, a := range awards {
+			total += a.Points
+		}
+		return total
+	case "last":
+		if len(awards) == 0 {
+			return 0
+		}
+		return awards[len(awards)-1].Points
+	default:
+		best := 0
+		for _, a := range awards {
+			if a.Points > best {
+				best = a.Points
 			}
-			lastTimestamp = ev.Timestamp
 		}
+		return best
 	}
+}
 
-	score := req.FinalBlockCount - 1
-	if score < 0 {
-		return 0
+// foldAwardsByCategory reduces an award log to the best score per category,
+// powering per-category leaderboards (e.g. "endurance", "speed").
+func foldAwardsByCategory(awards []Award) map[string]int {
+	best := make(map[string]int)
+	for _, a := range awards {
+		if a.Points > best[a.Category] {
+			best[a.Category] = a.Points
+		}
 	}
-	return score
+	return best
 }
 
 // ===== Exported Functions (HTTP Handlers) =====
 
-// getAll → Returns the full leaderboard as JSON
+// getAll → Returns the full leaderboard as JSON for the board named by the
+// `board_id` query param (default the implicit "default" board). Accepts an
+// optional `mode` query param (`max`, `sum`, or `category`) overriding the
+// board's configured operator for how each player's award log is folded.
 //
 //export getAll
 func getAll(e event.Event) uint32 {
@@ -81,36 +227,55 @@ func getAll(e event.Event) uint32 {
 		return 1
 	}
 
-	// Open leaderboard database
-	db, err := database.New("/leaderboard")
+	boardID := queryParam(h, "board_id", defaultBoardID)
+	cfg, err := loadBoardConfig(boardID)
 	if err != nil {
 		return fail(h, err, 500)
 	}
 
-	// List all player keys
-	keys, err := db.List("")
-	if err != nil {
-		return fail(h, err, 500)
-	}
+	mode := queryParam(h, "mode", operatorFoldMode(cfg.Operator))
 
-	// Sort player names alphabetically
-	sort.Strings(keys)
+	value, err := cache.getOrLoad("listing", cache.lruListing, boardID+"/getAll/"+mode, func() (interface{}, error) {
+		// Open board database
+		db, err := database.New(boardDataPath(boardID))
+		if err != nil {
+			return nil, err
+		}
 
-	// Collect {player_name, highest_score} entries
-	entries := make([]map[string]string, 0, len(keys))
-	for _, key := range keys {
-		value, err := db.Get(key)
+		// List all player keys
+		keys, err := db.List("")
 		if err != nil {
-			continue // skip if record is corrupted
+			return nil, err
 		}
-		entries = append(entries, map[string]string{
-			"player_name":   strings.Trim(key, "/"),
-			"highest_score": string(value),
-		})
+
+		// Sort player names alphabetically
+		sort.Strings(keys)
+
+		// Collect {player_name, score} entries by folding each player's award log
+		entries := make([]map[string]interface{}, 0, len(keys))
+		for _, key := range keys {
+			player := strings.Trim(key, "/")
+			awards, err := loadAwards(db, player)
+			if err != nil {
+				continue // skip if record is corrupted
+			}
+
+			entry := map[string]interface{}{"player_name": player}
+			if mode == "category" {
+				entry["categories"] = foldAwardsByCategory(awards)
+			} else {
+				entry["score"] = foldAwards(awards, mode)
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return fail(h, err, 500)
 	}
 
 	// Encode result as JSON and send back
-	jsonData, err := json.Marshal(entries)
+	jsonData, err := json.Marshal(value)
 	if err != nil {
 		return fail(h, err, 500)
 	}
@@ -121,7 +286,10 @@ func getAll(e event.Event) uint32 {
 	return 0
 }
 
-// get → Returns one player’s score (via query param `player_name`)
+// get → Returns one player's score (via query param `player_name`) on the
+// board named by `board_id` (default the implicit "default" board), folded
+// from their award log according to the optional `mode` query param (`max`,
+// `sum`, or `category`), which defaults to the board's configured operator.
 //
 //export get
 func get(e event.Event) uint32 {
@@ -132,30 +300,133 @@ func get(e event.Event) uint32 {
 	}
 
 	// Extract player_name from query string
-	key, err := h.Query().Get("player_name")
+	player, err := h.Query().Get("player_name")
 	if err != nil {
 		return fail(h, err, 400)
 	}
 
-	// Open leaderboard database
-	db, err := database.New("/leaderboard")
+	boardID := queryParam(h, "board_id", defaultBoardID)
+	cfg, err := loadBoardConfig(boardID)
 	if err != nil {
 		return fail(h, err, 500)
 	}
 
-	// Look up player's score
-	value, err := db.Get(key)
+	mode := queryParam(h, "mode", operatorFoldMode(cfg.Operator))
+
+	// Category scores aren't cached (lruPlayerScore holds one score per
+	// player, not a per-category map), so load the award log directly.
+	if mode == "category" {
+		db, err := database.New(boardDataPath(boardID))
+		if err != nil {
+			return fail(h, err, 500)
+		}
+		awards, err := loadAwards(db, player)
+		if err != nil {
+			return fail(h, err, 500)
+		}
+		if len(awards) == 0 {
+			return fail(h, errors.New("player not found"), 404)
+		}
+
+		jsonData, err := json.Marshal(foldAwardsByCategory(awards))
+		if err != nil {
+			return fail(h, err, 500)
+		}
+		h.Headers().Set("Content-Type", "application/json")
+		h.Write(jsonData)
+		h.Return(200)
+		return 0
+	}
+
+	value, err := cache.getOrLoad("player_score", cache.lruPlayerScore, boardID+"/"+player+"/"+mode, func() (interface{}, error) {
+		db, err := database.New(boardDataPath(boardID))
+		if err != nil {
+			return 0, err
+		}
+		awards, err := loadAwards(db, player)
+		if err != nil {
+			return 0, err
+		}
+		if len(awards) == 0 {
+			return 0, errPlayerNotFound
+		}
+		return foldAwards(awards, mode), nil
+	})
 	if err != nil {
-		return fail(h, err, 404) // not found
+		if errors.Is(err, errPlayerNotFound) {
+			return fail(h, err, 404)
+		}
+		return fail(h, err, 500)
 	}
 
-	// Send score as plain response
-	h.Write(value)
+	h.Write([]byte(strconv.Itoa(value.(int))))
 	h.Return(200)
 	return 0
 }
 
-// set → Submits/updates a player’s score if higher than existing
+// awards → Returns the raw award log as JSON for the board named by the
+// `board_id` query param (default the implicit "default" board), either for
+// a single player (via query param `player_name`) or, when omitted, every
+// player's log concatenated. This is the tamper-evident history a client
+// can replay.
+//
+//export awards
+func awards(e event.Event) uint32 {
+	// Parse HTTP request
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	boardID := queryParam(h, "board_id", defaultBoardID)
+
+	// Open board database
+	db, err := database.New(boardDataPath(boardID))
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	var all []Award
+	if player, err := h.Query().Get("player_name"); err == nil && player != "" {
+		all, err = loadAwards(db, player)
+		if err != nil {
+			return fail(h, err, 500)
+		}
+	} else {
+		keys, err := db.List("")
+		if err != nil {
+			return fail(h, err, 500)
+		}
+		for _, key := range keys {
+			playerAwards, err := loadAwards(db, strings.Trim(key, "/"))
+			if err != nil {
+				continue // skip if record is corrupted
+			}
+			all = append(all, playerAwards...)
+		}
+	}
+
+	jsonData, err := json.Marshal(all)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Headers().Set("Content-Type", "application/json")
+	h.Write(jsonData)
+	h.Return(200)
+	return 0
+}
+
+// set → Submits a player's game run, appending one award for the run's
+// verified tower height to their log. By default this targets the board
+// named by the `board_id` query param (default the implicit "default"
+// board); if a `tournament_id` query param is given instead, the submission
+// is routed into that tournament's currently active window, rejected with
+// 404 if the tournament doesn't exist, 400 if the submission arrives
+// outside the tournament's [start_time, end_time] span, and 403 if the
+// player hasn't joined via
+// joinTournament. Every submission is run through defaultValidators first;
+// failures are recorded to /rejected instead of being scored.
 //
 //export set
 func set(e event.Event) uint32 {
@@ -165,8 +436,13 @@ func set(e event.Event) uint32 {
 		return 1
 	}
 
-	// Open leaderboard database
-	db, err := database.New("/leaderboard")
+	dataPath, indexPath, foldMode, cacheBoardID, tournamentID, maintainIndex, err := resolveSetTarget(h)
+	if err != nil {
+		return fail(h, err, badRequestOr404(err))
+	}
+
+	// Open target database
+	db, err := database.New(dataPath)
 	if err != nil {
 		return fail(h, err, 500)
 	}
@@ -181,28 +457,267 @@ func set(e event.Event) uint32 {
 
 	// Validate input
 	if req.PlayerName == "" {
-		return fail(h, err, 400)
+		return fail(h, errors.New("player_name is required"), 400)
 	}
 
-	// Compute new score
-	newScore := computeScore(req)
-
-	// Check existing best score for player
-	existingBest := 0
-	if b, err := db.Get(req.PlayerName); err == nil && len(b) > 0 {
-		if v, convErr := strconv.Atoi(string(b)); convErr == nil {
-			existingBest = v
+	if tournamentID != "" {
+		member, err := isTournamentMember(tournamentID, req.PlayerName)
+		if err != nil {
+			return fail(h, err, 500)
+		}
+		if !member {
+			return fail(h, errors.New("player has not joined this tournament"), 403)
 		}
 	}
 
-	// Only update if new score is higher
-	if newScore > existingBest {
-		if err = db.Put(req.PlayerName, []byte(strconv.Itoa(newScore))); err != nil {
+	verifiedScore, reason, ok := validateSubmission(req, defaultValidators)
+	if !ok {
+		recordRejected(req, reason)
+		return fail(h, errors.New(reason), 400)
+	}
+
+	newAwards := deriveAwards(req, verifiedScore)
+	if len(newAwards) == 0 {
+		return fail(h, errors.New("no awards could be derived from submission"), 400)
+	}
+
+	oldScore, newScore, err := appendAwards(db, req.PlayerName, newAwards, foldMode)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	// High-write boards can opt out of the sorted index entirely.
+	if maintainIndex && newScore != oldScore {
+		if err = updateScoreIndex(indexPath, req.PlayerName, oldScore, newScore); err != nil {
 			return fail(h, err, 500)
 		}
 	}
 
+	if cacheBoardID != "" {
+		invalidateBoardCache(cacheBoardID, req.PlayerName, newScore)
+	}
+
 	// Respond success
 	h.Return(200)
 	return 0
 }
+
+// resolveSetTarget picks where `set` should store a submission: a named
+// board by default, or a tournament's active window when `tournament_id` is
+// given. It returns the award-log data path, the score index path, the fold
+// mode to rank by, and whether that index should be maintained at all.
+// cacheBoardID, when non-empty, is the board whose cache entries should be
+// invalidated after a successful set; it's empty for tournament submissions,
+// which the cache doesn't cover. tournamentID, when non-empty, tells set it
+// must check the player's membership before scoring the submission.
+func resolveSetTarget(h http.Event) (dataPath, indexPath, foldMode, cacheBoardID, tournamentID string, maintainIndex bool, err error) {
+	if tournamentID = queryParam(h, "tournament_id", ""); tournamentID != "" {
+		cfg, err := loadTournamentConfig(tournamentID)
+		if err != nil {
+			return "", "", "", "", "", false, err
+		}
+
+		now := time.Now().Unix()
+		if now < cfg.StartTime || now > cfg.EndTime {
+			return "", "", "", "", "", false, errors.New("tournament is not currently active")
+		}
+
+		windowStart, _ := computeWindow(now, cfg.StartTime, cfg.Duration, cfg.ResetSchedule)
+		return tournamentDataPath(tournamentID, windowStart), tournamentScoreIndexPath(tournamentID, windowStart), "max", "", tournamentID, true, nil
+	}
+
+	boardID := queryParam(h, "board_id", defaultBoardID)
+	cfg, err := loadBoardConfig(boardID)
+	if err != nil {
+		return "", "", "", "", "", false, err
+	}
+	return boardDataPath(boardID), boardScoreIndexPath(boardID), operatorFoldMode(cfg.Operator), boardID, "", cfg.EnableRanks, nil
+}
+
+// isTournamentMember reports whether a player has joined a tournament via
+// joinTournament.
+func isTournamentMember(tournamentID, player string) (bool, error) {
+	db, err := database.New(tournamentMembersPath(tournamentID))
+	if err != nil {
+		return false, err
+	}
+	raw, err := db.Get(player)
+	if err != nil || len(raw) == 0 {
+		return false, nil
+	}
+	return true, nil
+}
+
+// badRequestOr404 maps resolveSetTarget's sentinel "not found" error to 404,
+// and everything else (validation failures) to 400.
+func badRequestOr404(err error) int {
+	if errors.Is(err, errTournamentNotFound) {
+		return 404
+	}
+	return 400
+}
+
+// list → Returns a bounded, cursor-paginated page of the board named by the
+// `board_id` query param (default the implicit "default" board), sorted by
+// score (via its score secondary index) or by player name. Query params:
+// `limit` (default 50, max 200), `fromItem` (opaque cursor, the last item
+// key of the previous page), `order` (`asc`, `desc`, or `name`; default
+// `desc`).
+//
+//export list
+func list(e event.Event) uint32 {
+	// Parse HTTP request
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	boardID := queryParam(h, "board_id", defaultBoardID)
+	cfg, err := loadBoardConfig(boardID)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	limit := parseLimit(queryParam(h, "limit", ""))
+	fromItem := queryParam(h, "fromItem", "")
+	order := queryParam(h, "order", "desc")
+
+	if order != "name" && !cfg.EnableRanks {
+		return fail(h, errors.New("ranks are disabled for this board; use order=name"), 400)
+	}
+
+	cacheKey := boardID + "/" + order + "/" + fromItem + "/" + strconv.Itoa(limit)
+	page, err := cache.getOrLoad("listing", cache.lruListing, cacheKey, func() (interface{}, error) {
+		var keys []string
+		if order == "name" {
+			db, err := database.New(boardDataPath(boardID))
+			if err != nil {
+				return nil, err
+			}
+			keys, err = db.List("")
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(keys)
+		} else {
+			idx, err := database.New(boardScoreIndexPath(boardID))
+			if err != nil {
+				return nil, err
+			}
+			keys, err = idx.List("")
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(keys) // zero-padded scores sort lexically == numerically, ascending
+			if order == "desc" {
+				for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+					keys[i], keys[j] = keys[j], keys[i]
+				}
+			}
+		}
+
+		start := 0
+		if fromItem != "" {
+			for i, key := range keys {
+				if strings.Trim(key, "/") == fromItem {
+					start = i + 1
+					break
+				}
+			}
+		}
+		if start > len(keys) {
+			start = len(keys)
+		}
+		end := start + limit
+		if end > len(keys) {
+			end = len(keys)
+		}
+		pageKeys := keys[start:end]
+
+		items := make([]map[string]interface{}, 0, len(pageKeys))
+		for i, key := range pageKeys {
+			item, err := listItem(boardID, order, key)
+			if err != nil {
+				continue // skip if record is corrupted
+			}
+			if order != "name" {
+				item["rank"] = start + i + 1
+			}
+			items = append(items, item)
+		}
+
+		nextCursor := ""
+		if end < len(keys) {
+			nextCursor = strings.Trim(pageKeys[len(pageKeys)-1], "/")
+		}
+
+		return map[string]interface{}{
+			"items":         items,
+			"next_cursor":   nextCursor,
+			"pending_items": len(keys) - end,
+		}, nil
+	})
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	jsonData, err := json.Marshal(page)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Headers().Set("Content-Type", "application/json")
+	h.Write(jsonData)
+	h.Return(200)
+	return 0
+}
+
+// listItem turns one page key into the JSON entry returned by `list`. For
+// score-ordered pages the key itself (zero-padded score/player) is enough;
+// for name-ordered pages the player's award log still has to be folded.
+func listItem(boardID, order, key string) (map[string]interface{}, error) {
+	if order == "name" {
+		player := strings.Trim(key, "/")
+		db, err := database.New(boardDataPath(boardID))
+		if err != nil {
+			return nil, err
+		}
+		awards, err := loadAwards(db, player)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"player_name": player,
+			"score":       foldAwards(awards, "max"),
+		}, nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, "/"), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed score index key %q", key)
+	}
+	score, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"player_name": parts[1],
+		"score":       score,
+	}, nil
+}
+
+// parseLimit parses the `limit` query param, defaulting to defaultListLimit
+// and clamping to maxListLimit.
+func parseLimit(raw string) int {
+	if raw == "" {
+		return defaultListLimit
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return defaultListLimit
+	}
+	if n > maxListLimit {
+		return maxListLimit
+	}
+	return n
+}
@@ -0,0 +1,59 @@
+package lib
+
+import "testing"
+
+func TestFoldAwardsMax(t *testing.T) {
+	awards := []Award{
+		{PlayerName: "alice", Category: "height", Points: 3},
+		{PlayerName: "alice", Category: "height", Points: 9},
+		{PlayerName: "alice", Category: "height", Points: 5},
+	}
+	if got := foldAwards(awards, "max"); got != 9 {
+		t.Errorf("foldAwards(max) = %d, want 9", got)
+	}
+	if got := foldAwards(awards, ""); got != 9 {
+		t.Errorf("foldAwards(\"\") = %d, want 9 (max is the default)", got)
+	}
+}
+
+func TestFoldAwardsSum(t *testing.T) {
+	awards := []Award{
+		{PlayerName: "alice", Category: "height", Points: 3},
+		{PlayerName: "alice", Category: "height", Points: 9},
+	}
+	if got := foldAwards(awards, "sum"); got != 12 {
+		t.Errorf("foldAwards(sum) = %d, want 12", got)
+	}
+}
+
+func TestFoldAwardsLast(t *testing.T) {
+	awards := []Award{
+		{PlayerName: "alice", Category: "height", Points: 3},
+		{PlayerName: "alice", Category: "height", Points: 9},
+	}
+	if got := foldAwards(awards, "last"); got != 9 {
+		t.Errorf("foldAwards(last) = %d, want 9", got)
+	}
+	if got := foldAwards(nil, "last"); got != 0 {
+		t.Errorf("foldAwards(last) on empty log = %d, want 0", got)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want int
+	}{
+		{"", defaultListLimit},
+		{"garbage", defaultListLimit},
+		{"0", defaultListLimit},
+		{"-5", defaultListLimit},
+		{"25", 25},
+		{"1000", maxListLimit},
+	}
+	for _, c := range cases {
+		if got := parseLimit(c.raw); got != c.want {
+			t.Errorf("parseLimit(%q) = %d, want %d", c.raw, got, c.want)
+		}
+	}
+}
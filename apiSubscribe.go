@@ -0,0 +1,184 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taubyte/go-sdk/database"
+	"github.com/taubyte/go-sdk/event"
+	http "github.com/taubyte/go-sdk/http/event"
+)
+
+// Tuning for the `subscribe` long-lived stream.
+const (
+	defaultTopK           = 10
+	maxTopK               = 100
+	subscribePollInterval = 2 * time.Second
+	subscribeHeartbeat    = 30 * time.Second
+	subscribeMaxDuration  = 10 * time.Minute
+)
+
+// topKEntry is one ranked row of a board's score index.
+type topKEntry struct {
+	Player string
+	Score  int
+}
+
+// ScoreUpdateEvent is pushed to subscribers whenever a player in the
+// broadcast top-K has a new score (including on entering the top-K).
+type ScoreUpdateEvent struct {
+	Type   string `json:"type"`
+	Player string `json:"player"`
+	Old    int    `json:"old"`
+	New    int    `json:"new"`
+	Rank   int    `json:"rank"`
+}
+
+// subscribe → Streams live top-K leaderboard updates for the board named by
+// the `board_id` query param as Server-Sent Events. Emits a `snapshot` frame
+// immediately, a `score_update` frame whenever a player in the top-K has a
+// new score (a rank shift alone emits nothing), and a `heartbeat` frame
+// every 30s so
+// intermediaries don't close the connection. Query param `topk` selects the
+// tracked depth (default 10, max 100).
+//
+//export subscribe
+func subscribe(e event.Event) uint32 {
+	// Parse HTTP request
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	boardID := queryParam(h, "board_id", defaultBoardID)
+	topK := parseTopK(queryParam(h, "topk", ""))
+
+	h.Headers().Set("Content-Type", "text/event-stream")
+	h.Headers().Set("Cache-Control", "no-cache")
+	h.Headers().Set("Connection", "keep-alive")
+
+	prev, err := loadTopK(boardID, topK)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	writeSSE(h, "snapshot", map[string]interface{}{"type": "snapshot", "top_k": prev})
+
+	deadline := time.Now().Add(subscribeMaxDuration)
+	lastHeartbeat := time.Now()
+
+	for time.Now().Before(deadline) {
+		time.Sleep(subscribePollInterval)
+
+		current, err := loadTopK(boardID, topK)
+		if err != nil {
+			continue
+		}
+
+		for _, update := range diffTopK(prev, current) {
+			writeSSE(h, "score_update", update)
+		}
+		prev = current
+
+		if time.Since(lastHeartbeat) >= subscribeHeartbeat {
+			writeSSE(h, "heartbeat", map[string]string{"type": "heartbeat"})
+			lastHeartbeat = time.Now()
+		}
+	}
+
+	h.Return(200)
+	return 0
+}
+
+// loadTopK reads a board's score secondary index and returns its top-K
+// entries, highest score first, without rescanning the full award log.
+func loadTopK(boardID string, k int) ([]topKEntry, error) {
+	value, err := cache.getOrLoad("top_k", cache.lruTopK, cachedTopKKey(boardID, k), func() (interface{}, error) {
+		idx, err := database.New(boardScoreIndexPath(boardID))
+		if err != nil {
+			return nil, err
+		}
+		keys, err := idx.List("")
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(keys) // zero-padded scores sort lexically == numerically, ascending
+
+		entries := make([]topKEntry, 0, k)
+		for i := len(keys) - 1; i >= 0 && len(entries) < k; i-- {
+			parts := strings.SplitN(strings.TrimPrefix(keys[i], "/"), "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			score, err := strconv.Atoi(parts[0])
+			if err != nil {
+				continue
+			}
+			entries = append(entries, topKEntry{Player: parts[1], Score: score})
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]topKEntry), nil
+}
+
+// diffTopK compares two top-K snapshots and returns a score_update event for
+// every player in current whose score differs from prev or who was absent
+// from prev. Players whose score is unchanged get no event, even if their
+// rank moved.
+func diffTopK(prev, current []topKEntry) []ScoreUpdateEvent {
+	prevByPlayer := make(map[string]topKEntry, len(prev))
+	for _, e := range prev {
+		prevByPlayer[e.Player] = e
+	}
+
+	var updates []ScoreUpdateEvent
+	for rank, e := range current {
+		old, existed := prevByPlayer[e.Player]
+		if existed && old.Score == e.Score {
+			continue
+		}
+		oldScore := 0
+		if existed {
+			oldScore = old.Score
+		}
+		updates = append(updates, ScoreUpdateEvent{
+			Type:   "score_update",
+			Player: e.Player,
+			Old:    oldScore,
+			New:    e.Score,
+			Rank:   rank + 1,
+		})
+	}
+	return updates
+}
+
+// writeSSE writes one Server-Sent Events frame.
+func writeSSE(h http.Event, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	h.Write([]byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event, data)))
+}
+
+// parseTopK parses the `topk` query param, defaulting to defaultTopK and
+// clamping to maxTopK.
+func parseTopK(raw string) int {
+	if raw == "" {
+		return defaultTopK
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return defaultTopK
+	}
+	if n > maxTopK {
+		return maxTopK
+	}
+	return n
+}
@@ -0,0 +1,284 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taubyte/go-sdk/database"
+	"github.com/taubyte/go-sdk/event"
+)
+
+// Path of the tournament configuration database, keyed by tournament id.
+const tournamentsConfigDB = "/tournaments_config"
+
+// errTournamentNotFound is returned by loadTournamentConfig so callers can
+// distinguish "doesn't exist" from other failures.
+var errTournamentNotFound = errors.New("tournament not found")
+
+// TournamentConfig describes a time-windowed competition layered on top of
+// the board subsystem: an overall [start_time, end_time] span, optionally
+// reset on a recurring cadence named by `reset_schedule` (one of
+// scheduleToPeriod's keys, e.g. "daily", "weekly"). Without a
+// `reset_schedule`, the tournament is a single window of `duration` seconds
+// starting at start_time.
+type TournamentConfig struct {
+	ID            string `json:"id"`
+	StartTime     int64  `json:"start_time"`
+	EndTime       int64  `json:"end_time"`
+	Duration      int64  `json:"duration"`
+	ResetSchedule string `json:"reset_schedule,omitempty"`
+}
+
+// scheduleToPeriod maps a `reset_schedule` label to its period in seconds.
+// createTournament rejects any non-empty label that isn't a key here, so a
+// typo can't silently fall back to a single unbounded window.
+var scheduleToPeriod = map[string]int64{
+	"daily":  24 * 60 * 60,
+	"weekly": 7 * 24 * 60 * 60,
+}
+
+// tournamentDataPath returns the database path storing a tournament
+// window's award logs, keyed by player.
+func tournamentDataPath(id string, windowStart int64) string {
+	return fmt.Sprintf("/tournaments/%s/%d", id, windowStart)
+}
+
+// tournamentScoreIndexPath returns the database path storing a tournament
+// window's sorted score → player secondary index.
+func tournamentScoreIndexPath(id string, windowStart int64) string {
+	return fmt.Sprintf("/tournaments_by_score/%s/%d", id, windowStart)
+}
+
+// tournamentMembersPath returns the database path tracking which players
+// have joined a tournament.
+func tournamentMembersPath(id string) string {
+	return "/tournaments_members/" + id
+}
+
+// loadTournamentConfig returns a tournament's configuration, or
+// errTournamentNotFound if it hasn't been created.
+func loadTournamentConfig(id string) (TournamentConfig, error) {
+	db, err := database.New(tournamentsConfigDB)
+	if err != nil {
+		return TournamentConfig{}, err
+	}
+
+	raw, err := db.Get(id)
+	if err != nil || len(raw) == 0 {
+		return TournamentConfig{}, errTournamentNotFound
+	}
+
+	var cfg TournamentConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return TournamentConfig{}, err
+	}
+	return cfg, nil
+}
+
+// computeWindow returns the [windowStart, windowEnd) bounds of the
+// currently active window for a tournament, given its configured start
+// time, window duration, and optional recurring reset schedule. A
+// recognized schedule (a key of scheduleToPeriod) recurs every period from
+// start, regardless of duration; without one, the whole window is
+// [start, start+duration).
+func computeWindow(now, start, duration int64, schedule string) (windowStart, windowEnd int64) {
+	period, recurring := scheduleToPeriod[schedule]
+	if !recurring {
+		if duration <= 0 {
+			duration = 1
+		}
+		return start, start + duration
+	}
+
+	elapsed := now - start
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	windowStart = start + (elapsed/period)*period
+	return windowStart, windowStart + period
+}
+
+// createTournament → Creates or replaces a tournament's configuration.
+// Body: {"id", "start_time", "end_time", "duration", "reset_schedule"}.
+//
+//export createTournament
+func createTournament(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	var cfg TournamentConfig
+	dec := json.NewDecoder(h.Body())
+	defer h.Body().Close()
+	if err = dec.Decode(&cfg); err != nil {
+		return fail(h, err, 400)
+	}
+
+	if cfg.ID == "" {
+		return fail(h, errors.New("id is required"), 400)
+	}
+	if cfg.EndTime <= cfg.StartTime {
+		return fail(h, errors.New("end_time must be after start_time"), 400)
+	}
+	if cfg.ResetSchedule != "" {
+		if _, ok := scheduleToPeriod[cfg.ResetSchedule]; !ok {
+			return fail(h, errors.New("reset_schedule must be one of: daily, weekly"), 400)
+		}
+	}
+
+	db, err := database.New(tournamentsConfigDB)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	if err = db.Put(cfg.ID, raw); err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Return(200)
+	return 0
+}
+
+// joinTournament → Registers a player as a tournament participant (query
+// params `tournament_id`, `player_name`).
+//
+//export joinTournament
+func joinTournament(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	tournamentID, err := h.Query().Get("tournament_id")
+	if err != nil || tournamentID == "" {
+		return fail(h, errors.New("tournament_id is required"), 400)
+	}
+	player, err := h.Query().Get("player_name")
+	if err != nil || player == "" {
+		return fail(h, errors.New("player_name is required"), 400)
+	}
+
+	if _, err := loadTournamentConfig(tournamentID); err != nil {
+		return fail(h, err, 404)
+	}
+
+	db, err := database.New(tournamentMembersPath(tournamentID))
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	if err = db.Put(player, []byte("1")); err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Return(200)
+	return 0
+}
+
+// listTournamentRecords → Returns a bounded, cursor-paginated page of the
+// currently active window's scores for a tournament (query param
+// `tournament_id`), sorted by score. Accepts the same `limit`, `fromItem`,
+// and `order` (`asc`/`desc`) query params as `list`.
+//
+//export listTournamentRecords
+func listTournamentRecords(e event.Event) uint32 {
+	h, err := e.HTTP()
+	if err != nil {
+		return 1
+	}
+
+	tournamentID, err := h.Query().Get("tournament_id")
+	if err != nil || tournamentID == "" {
+		return fail(h, errors.New("tournament_id is required"), 400)
+	}
+
+	cfg, err := loadTournamentConfig(tournamentID)
+	if err != nil {
+		return fail(h, err, 404)
+	}
+
+	windowStart, _ := computeWindow(time.Now().Unix(), cfg.StartTime, cfg.Duration, cfg.ResetSchedule)
+
+	limit := parseLimit(queryParam(h, "limit", ""))
+	fromItem := queryParam(h, "fromItem", "")
+	order := queryParam(h, "order", "desc")
+
+	idx, err := database.New(tournamentScoreIndexPath(tournamentID, windowStart))
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	keys, err := idx.List("")
+	if err != nil {
+		return fail(h, err, 500)
+	}
+	sort.Strings(keys) // zero-padded scores sort lexically == numerically, ascending
+	if order == "desc" {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	start := 0
+	if fromItem != "" {
+		for i, key := range keys {
+			if strings.Trim(key, "/") == fromItem {
+				start = i + 1
+				break
+			}
+		}
+	}
+	if start > len(keys) {
+		start = len(keys)
+	}
+	end := start + limit
+	if end > len(keys) {
+		end = len(keys)
+	}
+	page := keys[start:end]
+
+	items := make([]map[string]interface{}, 0, len(page))
+	for i, key := range page {
+		parts := strings.SplitN(strings.TrimPrefix(key, "/"), "/", 2)
+		if len(parts) != 2 {
+			continue // skip if record is corrupted
+		}
+		score, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"player_name": parts[1],
+			"score":       score,
+			"rank":        start + i + 1,
+		})
+	}
+
+	nextCursor := ""
+	if end < len(keys) {
+		nextCursor = strings.Trim(page[len(page)-1], "/")
+	}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"window_start":  windowStart,
+		"items":         items,
+		"next_cursor":   nextCursor,
+		"pending_items": len(keys) - end,
+	})
+	if err != nil {
+		return fail(h, err, 500)
+	}
+
+	h.Headers().Set("Content-Type", "application/json")
+	h.Write(jsonData)
+	h.Return(200)
+	return 0
+}
@@ -0,0 +1,49 @@
+package lib
+
+import "testing"
+
+func TestComputeWindowNoSchedule(t *testing.T) {
+	start, end := computeWindow(1000, 500, 300, "")
+	if start != 500 || end != 800 {
+		t.Errorf("computeWindow(no schedule) = [%d, %d), want [500, 800)", start, end)
+	}
+}
+
+func TestComputeWindowDaily(t *testing.T) {
+	const day = 24 * 60 * 60
+	start := int64(1000)
+
+	// Still inside the first window.
+	windowStart, windowEnd := computeWindow(start+100, start, 999, "daily")
+	if windowStart != start || windowEnd != start+day {
+		t.Errorf("computeWindow(daily, +100s) = [%d, %d), want [%d, %d)", windowStart, windowEnd, start, start+day)
+	}
+
+	// One full day later should be the next window, regardless of duration.
+	windowStart, windowEnd = computeWindow(start+day+50, start, 999, "daily")
+	if windowStart != start+day || windowEnd != start+2*day {
+		t.Errorf("computeWindow(daily, +1 day) = [%d, %d), want [%d, %d)", windowStart, windowEnd, start+day, start+2*day)
+	}
+}
+
+func TestComputeWindowWeeklyDiffersFromDaily(t *testing.T) {
+	const day = 24 * 60 * 60
+	start := int64(0)
+	now := int64(3 * day) // three days in
+
+	dailyStart, _ := computeWindow(now, start, 999, "daily")
+	weeklyStart, _ := computeWindow(now, start, 999, "weekly")
+	if dailyStart == weeklyStart {
+		t.Errorf("daily and weekly schedules produced the same window start (%d); they should differ", dailyStart)
+	}
+}
+
+func TestComputeWindowClampsNegativeElapsed(t *testing.T) {
+	windowStart, windowEnd := computeWindow(100, 500, 999, "daily")
+	if windowStart != 500 {
+		t.Errorf("computeWindow before start_time: windowStart = %d, want 500", windowStart)
+	}
+	if windowEnd != 500+24*60*60 {
+		t.Errorf("computeWindow before start_time: windowEnd = %d, want %d", windowEnd, 500+24*60*60)
+	}
+}